@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsWafv2WebACLLoggingConfigurationRead,
+
+		Schema: map[string]*schema.Schema{
+			"log_destination_configs": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"logging_filter": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_behavior": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"filter": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"behavior": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"requirement": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"condition": {
+										Type:     schema.TypeSet,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"action_condition": {
+													Type:     schema.TypeList,
+													Computed: true,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"action": {
+																Type:     schema.TypeString,
+																Computed: true,
+															},
+														},
+													},
+												},
+												"label_name_condition": {
+													Type:     schema.TypeList,
+													Computed: true,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"label_name": {
+																Type:     schema.TypeString,
+																Computed: true,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"redacted_fields": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"method":       {Type: schema.TypeList, Computed: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{}}},
+						"query_string": {Type: schema.TypeList, Computed: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{}}},
+						"single_header": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"uri_path": {Type: schema.TypeList, Computed: true, Elem: &schema.Resource{Schema: map[string]*schema.Schema{}}},
+					},
+				},
+			},
+			"resource_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func dataSourceAwsWafv2WebACLLoggingConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).wafv2conn
+
+	resourceArn := d.Get("resource_arn").(string)
+	input := &wafv2.GetLoggingConfigurationInput{
+		ResourceArn: aws.String(resourceArn),
+	}
+
+	output, err := conn.GetLoggingConfiguration(input)
+	if err != nil {
+		return fmt.Errorf("error reading WAFv2 Logging Configuration for resource (%s): %w", resourceArn, err)
+	}
+	if output == nil || output.LoggingConfiguration == nil {
+		return fmt.Errorf("error reading WAFv2 Logging Configuration for resource (%s): empty response", resourceArn)
+	}
+
+	d.SetId(aws.StringValue(output.LoggingConfiguration.ResourceArn))
+
+	if err := d.Set("log_destination_configs", flattenStringList(output.LoggingConfiguration.LogDestinationConfigs)); err != nil {
+		return fmt.Errorf("error setting log_destination_configs: %w", err)
+	}
+
+	if err := d.Set("redacted_fields", flattenWafv2RedactedFields(output.LoggingConfiguration.RedactedFields)); err != nil {
+		return fmt.Errorf("error setting redacted_fields: %w", err)
+	}
+
+	if err := d.Set("logging_filter", flattenWafv2LoggingFilter(output.LoggingConfiguration.LoggingFilter)); err != nil {
+		return fmt.Errorf("error setting logging_filter: %w", err)
+	}
+
+	return nil
+}
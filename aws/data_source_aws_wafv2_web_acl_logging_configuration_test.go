@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccAWSWafv2WebACLLoggingConfigurationDataSource_basic(t *testing.T) {
+	resourceName := "aws_wafv2_web_acl_logging_configuration.test"
+	dataSourceName := "data.aws_wafv2_web_acl_logging_configuration.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafv2WebACLLoggingConfigurationDataSourceConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "resource_arn", resourceName, "resource_arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "log_destination_configs.#", resourceName, "log_destination_configs.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "redacted_fields.#", resourceName, "redacted_fields.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "logging_filter.#", resourceName, "logging_filter.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationDataSourceConfig_basic(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_cloudwatch_log_group.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+
+  redacted_fields {
+    single_header {
+      name = "authorization"
+    }
+  }
+}
+
+data "aws_wafv2_web_acl_logging_configuration" "test" {
+  resource_arn = aws_wafv2_web_acl_logging_configuration.test.resource_arn
+}
+`, rName)
+}
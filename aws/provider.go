@@ -0,0 +1,17 @@
+package aws
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		DataSourcesMap: map[string]*schema.Resource{
+			"aws_wafv2_web_acl_logging_configuration": dataSourceAwsWafv2WebACLLoggingConfiguration(),
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_wafv2_web_acl_logging_configuration": resourceAwsWafv2WebACLLoggingConfiguration(),
+		},
+	}
+}
@@ -3,11 +3,15 @@ package aws
 import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
 )
 
 func resourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
@@ -21,6 +25,11 @@ func resourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: customdiff.All(
+			resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiff,
+			resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiffLoggingFilter,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"log_destination_configs": {
 				Type:     schema.TypeSet,
@@ -30,9 +39,9 @@ func resourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
 				MaxItems: 100,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validateArn,
+					ValidateFunc: validateWafv2LogDestinationConfig,
 				},
-				Description: "AWS Kinesis Firehose Delivery Stream ARNs",
+				Description: "Amazon Kinesis Data Firehose Delivery Stream, CloudWatch Log Group, or S3 Bucket ARNs to receive logs",
 			},
 			"redacted_fields": {
 				// To allow this argument and its nested fields with Empty Schemas (e.g. "method")
@@ -96,6 +105,77 @@ func resourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
 				Description:      "Parts of the request to exclude from logs",
 				DiffSuppressFunc: suppressEquivalentRedactedFields,
 			},
+			"logging_filter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_behavior": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(wafv2.FilterBehavior_Values(), false),
+						},
+						"filter": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"behavior": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(wafv2.FilterBehavior_Values(), false),
+									},
+									"requirement": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(wafv2.FilterRequirement_Values(), false),
+									},
+									"condition": {
+										Type:     schema.TypeSet,
+										Required: true,
+										MinItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"action_condition": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"action": {
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validation.StringInSlice(wafv2.ActionValue_Values(), false),
+															},
+														},
+													},
+												},
+												"label_name_condition": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"label_name": {
+																Type:         schema.TypeString,
+																Required:     true,
+																ValidateFunc: validation.StringLenBetween(1, 1024),
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Description: "Filters for the logs that specify which web requests are kept or dropped",
+			},
 			"resource_arn": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -107,59 +187,74 @@ func resourceAwsWafv2WebACLLoggingConfiguration() *schema.Resource {
 	}
 }
 
-// suppressEquivalentRedactedFields is required to
-// handle shifts in List ordering returned from the API
+// suppressEquivalentRedactedFields handles shifts in list ordering returned
+// from the API by canonicalizing each redacted field into a stable string
+// key and comparing the old/new sets of keys as multisets, rather than
+// doing a pairwise nested comparison.
 func suppressEquivalentRedactedFields(k, old, new string, d *schema.ResourceData) bool {
 	o, n := d.GetChange("redacted_fields")
-	if o != nil && n != nil {
-		oldFields := o.([]interface{})
-		newFields := n.([]interface{})
-		if len(oldFields) != len(newFields) {
+	oldFields, ok := o.([]interface{})
+	if !ok {
+		return false
+	}
+	newFields, ok := n.([]interface{})
+	if !ok {
+		return false
+	}
+
+	oldKeys := canonicalizeWafv2RedactedFields(oldFields)
+	newKeys := canonicalizeWafv2RedactedFields(newFields)
+
+	if len(oldKeys) != len(newKeys) {
+		return false
+	}
+
+	sort.Strings(oldKeys)
+	sort.Strings(newKeys)
+
+	for i := range oldKeys {
+		if oldKeys[i] != newKeys[i] {
 			return false
 		}
+	}
 
-		for _, oldField := range oldFields {
-			om := oldField.(map[string]interface{})
-			found := false
-			for _, newField := range newFields {
-				nm := newField.(map[string]interface{})
-				if len(om) != len(nm) {
-					continue
-				}
-				for k, newVal := range nm {
-					if oldVal, ok := om[k]; ok {
-						if k == "method" || k == "query_string" || k == "uri_path" {
-							if len(oldVal.([]interface{})) == len(newVal.([]interface{})) {
-								found = true
-								break
-							}
-						} else if k == "single_header" {
-							oldHeader := oldVal.([]interface{})
-							newHeader := newVal.([]interface{})
-							if len(oldHeader) > 0 && oldHeader[0] != nil {
-								if len(newHeader) > 0 && newHeader[0] != nil {
-									oldName := oldVal.([]interface{})[0].(map[string]interface{})["name"].(string)
-									newName := newVal.([]interface{})[0].(map[string]interface{})["name"].(string)
-									if oldName == newName {
-										found = true
-										break
-									}
-								}
-							}
-						}
-					}
-				}
-				if found {
-					break
-				}
-			}
-			if !found {
-				return false
-			}
+	return true
+}
+
+// canonicalizeWafv2RedactedFields reduces a redacted_fields list to a stable,
+// order-independent set of keys suitable for multiset comparison.
+func canonicalizeWafv2RedactedFields(fields []interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		m, ok := field.(map[string]interface{})
+		if !ok {
+			continue
 		}
-		return true
+		keys = append(keys, canonicalizeWafv2RedactedField(m)...)
+	}
+	return keys
+}
+
+// canonicalizeWafv2RedactedField canonicalizes a single redacted_fields entry.
+func canonicalizeWafv2RedactedField(m map[string]interface{}) []string {
+	if v, ok := m["method"].([]interface{}); ok && len(v) > 0 {
+		return []string{"method:"}
+	}
+	if v, ok := m["query_string"].([]interface{}); ok && len(v) > 0 {
+		return []string{"query_string:"}
+	}
+	if v, ok := m["uri_path"].([]interface{}); ok && len(v) > 0 {
+		return []string{"uri_path:"}
+	}
+	if v, ok := m["single_query_argument"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		name, _ := v[0].(map[string]interface{})["name"].(string)
+		return []string{"single_query_argument:" + strings.ToLower(name)}
 	}
-	return false
+	if v, ok := m["single_header"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		name, _ := v[0].(map[string]interface{})["name"].(string)
+		return []string{"single_header:" + strings.ToLower(name)}
+	}
+	return nil
 }
 
 func resourceAwsWafv2WebACLLoggingConfigurationPut(d *schema.ResourceData, meta interface{}) error {
@@ -181,6 +276,14 @@ func resourceAwsWafv2WebACLLoggingConfigurationPut(d *schema.ResourceData, meta
 		config.RedactedFields = []*wafv2.FieldToMatch{}
 	}
 
+	if v, ok := d.GetOk("logging_filter"); ok && len(v.([]interface{})) > 0 {
+		filter, err := expandWafv2LoggingFilter(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		config.LoggingFilter = filter
+	}
+
 	input := &wafv2.PutLoggingConfigurationInput{
 		LoggingConfiguration: config,
 	}
@@ -223,6 +326,10 @@ func resourceAwsWafv2WebACLLoggingConfigurationRead(d *schema.ResourceData, meta
 		return fmt.Errorf("error setting redacted_fields: %w", err)
 	}
 
+	if err := d.Set("logging_filter", flattenWafv2LoggingFilter(output.LoggingConfiguration.LoggingFilter)); err != nil {
+		return fmt.Errorf("error setting logging_filter: %w", err)
+	}
+
 	d.Set("resource_arn", output.LoggingConfiguration.ResourceArn)
 
 	return nil
@@ -241,6 +348,109 @@ func resourceAwsWafv2WebACLLoggingConfigurationDelete(d *schema.ResourceData, me
 	return nil
 }
 
+// resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiff rejects a
+// log_destination_configs set that mixes destination types. The WAFv2
+// PutLoggingConfiguration API accepts only one destination type (Kinesis
+// Data Firehose, CloudWatch Logs, or S3) per call; validateWafv2LogDestinationConfig
+// only checks each ARN in isolation, so a mixed set would otherwise pass
+// client-side validation and fail remotely.
+func resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	v, ok := diff.GetOk("log_destination_configs")
+	if !ok {
+		return nil
+	}
+
+	var destinationType string
+	for _, c := range v.(*schema.Set).List() {
+		arnString, ok := c.(string)
+		if !ok || arnString == "" {
+			continue
+		}
+
+		parsedArn, err := arn.Parse(arnString)
+		if err != nil {
+			// an invalid ARN is caught by validateWafv2LogDestinationConfig
+			continue
+		}
+
+		if destinationType == "" {
+			destinationType = parsedArn.Service
+			continue
+		}
+
+		if parsedArn.Service != destinationType {
+			return fmt.Errorf("log_destination_configs must all be the same destination type (Kinesis Data Firehose, CloudWatch Logs, or S3); got both %q and %q", destinationType, parsedArn.Service)
+		}
+	}
+
+	return nil
+}
+
+// resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiffLoggingFilter rejects
+// a logging_filter condition that sets zero or both of action_condition and
+// label_name_condition. The schema can't express this with ExactlyOneOf
+// because condition is nested inside a TypeSet, so it's enforced here instead
+// of only at apply time in expandWafv2Conditions.
+func resourceAwsWafv2WebACLLoggingConfigurationCustomizeDiffLoggingFilter(diff *schema.ResourceDiff, meta interface{}) error {
+	v, ok := diff.GetOk("logging_filter")
+	if !ok {
+		return nil
+	}
+
+	loggingFilter := v.([]interface{})
+	if len(loggingFilter) == 0 || loggingFilter[0] == nil {
+		return nil
+	}
+
+	filters := loggingFilter[0].(map[string]interface{})["filter"].(*schema.Set)
+	for _, f := range filters.List() {
+		conditions := f.(map[string]interface{})["condition"].(*schema.Set)
+		for _, c := range conditions.List() {
+			condition := c.(map[string]interface{})
+			hasActionCondition := len(condition["action_condition"].([]interface{})) > 0
+			hasLabelNameCondition := len(condition["label_name_condition"].([]interface{})) > 0
+
+			if hasActionCondition == hasLabelNameCondition {
+				return fmt.Errorf(`logging_filter condition: exactly one of "action_condition" or "label_name_condition" must be set`)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateWafv2LogDestinationConfig ensures the supplied ARN identifies a
+// Kinesis Data Firehose delivery stream, a CloudWatch Logs log group, or an
+// S3 bucket, and that its name carries the "aws-waf-logs-" prefix required
+// by the WAFv2 logging configuration API.
+func validateWafv2LogDestinationConfig(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	parsedArn, err := arn.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid ARN: %w", k, value, err))
+		return
+	}
+
+	switch parsedArn.Service {
+	case "firehose":
+		if !strings.HasPrefix(parsedArn.Resource, "deliverystream/aws-waf-logs-") {
+			errors = append(errors, fmt.Errorf("%q (%s) must be a Kinesis Data Firehose delivery stream ARN with a name prefixed with \"aws-waf-logs-\"", k, value))
+		}
+	case "logs":
+		if !strings.HasPrefix(parsedArn.Resource, "log-group:aws-waf-logs-") {
+			errors = append(errors, fmt.Errorf("%q (%s) must be a CloudWatch Logs log group ARN with a name prefixed with \"aws-waf-logs-\"", k, value))
+		}
+	case "s3":
+		if !strings.HasPrefix(parsedArn.Resource, "aws-waf-logs-") {
+			errors = append(errors, fmt.Errorf("%q (%s) must be an S3 bucket ARN with a name prefixed with \"aws-waf-logs-\"", k, value))
+		}
+	default:
+		errors = append(errors, fmt.Errorf("%q (%s) must be an ARN for a Kinesis Data Firehose delivery stream, a CloudWatch Logs log group, or an S3 bucket", k, value))
+	}
+
+	return
+}
+
 func expandWafv2RedactedFields(fields []interface{}) ([]*wafv2.FieldToMatch, error) {
 	redactedFields := make([]*wafv2.FieldToMatch, 0, len(fields))
 	for _, field := range fields {
@@ -248,16 +458,14 @@ func expandWafv2RedactedFields(fields []interface{}) ([]*wafv2.FieldToMatch, err
 		if err != nil {
 			return nil, err
 		}
-		redactedFields = append(redactedFields, f)
+		redactedFields = append(redactedFields, f...)
 	}
 	return redactedFields, nil
 }
 
-func expandWafv2RedactedField(field interface{}) (*wafv2.FieldToMatch, error) {
+func expandWafv2RedactedField(field interface{}) ([]*wafv2.FieldToMatch, error) {
 	m := field.(map[string]interface{})
 
-	f := &wafv2.FieldToMatch{}
-
 	// While the FieldToMatch struct allows more than 1 of its fields to be set,
 	// the WAFv2 API does not. In addition, in the context of Logging Configuration requests,
 	// the WAFv2 API only supports the following redacted fields.
@@ -274,16 +482,17 @@ func expandWafv2RedactedField(field interface{}) (*wafv2.FieldToMatch, error) {
 	}
 
 	if v, ok := m["method"]; ok && len(v.([]interface{})) > 0 {
-		f.Method = &wafv2.Method{}
+		return []*wafv2.FieldToMatch{{Method: &wafv2.Method{}}}, nil
 	} else if v, ok := m["query_string"]; ok && len(v.([]interface{})) > 0 {
-		f.QueryString = &wafv2.QueryString{}
-	} else if v, ok := m["single_header"]; ok && len(v.([]interface{})) > 0 {
-		f.SingleHeader = expandWafv2SingleHeader(m["single_header"].([]interface{}))
+		return []*wafv2.FieldToMatch{{QueryString: &wafv2.QueryString{}}}, nil
+	} else if v, ok := m["single_header"]; ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		name, _ := v.([]interface{})[0].(map[string]interface{})["name"].(string)
+		return []*wafv2.FieldToMatch{{SingleHeader: &wafv2.SingleHeader{Name: aws.String(name)}}}, nil
 	} else if v, ok := m["uri_path"]; ok && len(v.([]interface{})) > 0 {
-		f.UriPath = &wafv2.UriPath{}
+		return []*wafv2.FieldToMatch{{UriPath: &wafv2.UriPath{}}}, nil
 	}
 
-	return f, nil
+	return []*wafv2.FieldToMatch{{}}, nil
 }
 
 func flattenWafv2RedactedFields(fields []*wafv2.FieldToMatch) []map[string]interface{} {
@@ -322,3 +531,145 @@ func flattenWafv2RedactedField(f *wafv2.FieldToMatch) map[string]interface{} {
 
 	return m
 }
+
+func expandWafv2LoggingFilter(l []interface{}) (*wafv2.LoggingFilter, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	filters, err := expandWafv2Filters(m["filter"].(*schema.Set).List())
+	if err != nil {
+		return nil, err
+	}
+
+	return &wafv2.LoggingFilter{
+		DefaultBehavior: aws.String(m["default_behavior"].(string)),
+		Filters:         filters,
+	}, nil
+}
+
+func expandWafv2Filters(l []interface{}) ([]*wafv2.Filter, error) {
+	filters := make([]*wafv2.Filter, 0, len(l))
+
+	for _, item := range l {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditions, err := expandWafv2Conditions(m["condition"].(*schema.Set).List())
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, &wafv2.Filter{
+			Behavior:    aws.String(m["behavior"].(string)),
+			Requirement: aws.String(m["requirement"].(string)),
+			Conditions:  conditions,
+		})
+	}
+
+	return filters, nil
+}
+
+func expandWafv2Conditions(l []interface{}) ([]*wafv2.Condition, error) {
+	conditions := make([]*wafv2.Condition, 0, len(l))
+
+	for _, item := range l {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condition := &wafv2.Condition{}
+
+		hasActionCondition := false
+		if v, ok := m["action_condition"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			condition.ActionCondition = &wafv2.ActionCondition{
+				Action: aws.String(v[0].(map[string]interface{})["action"].(string)),
+			}
+			hasActionCondition = true
+		}
+
+		hasLabelNameCondition := false
+		if v, ok := m["label_name_condition"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			condition.LabelNameCondition = &wafv2.LabelNameCondition{
+				LabelName: aws.String(v[0].(map[string]interface{})["label_name"].(string)),
+			}
+			hasLabelNameCondition = true
+		}
+
+		if hasActionCondition == hasLabelNameCondition {
+			return nil, fmt.Errorf(`error expanding condition: exactly one of "action_condition" or "label_name_condition" must be set`)
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+func flattenWafv2LoggingFilter(filter *wafv2.LoggingFilter) []interface{} {
+	if filter == nil {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"default_behavior": aws.StringValue(filter.DefaultBehavior),
+		"filter":           flattenWafv2Filters(filter.Filters),
+	}
+
+	return []interface{}{m}
+}
+
+func flattenWafv2Filters(filters []*wafv2.Filter) []interface{} {
+	out := make([]interface{}, 0, len(filters))
+
+	for _, f := range filters {
+		if f == nil {
+			continue
+		}
+
+		out = append(out, map[string]interface{}{
+			"behavior":    aws.StringValue(f.Behavior),
+			"requirement": aws.StringValue(f.Requirement),
+			"condition":   flattenWafv2Conditions(f.Conditions),
+		})
+	}
+
+	return out
+}
+
+func flattenWafv2Conditions(conditions []*wafv2.Condition) []interface{} {
+	out := make([]interface{}, 0, len(conditions))
+
+	for _, c := range conditions {
+		if c == nil {
+			continue
+		}
+
+		m := map[string]interface{}{}
+
+		if c.ActionCondition != nil {
+			m["action_condition"] = []interface{}{
+				map[string]interface{}{
+					"action": aws.StringValue(c.ActionCondition.Action),
+				},
+			}
+		}
+
+		if c.LabelNameCondition != nil {
+			m["label_name_condition"] = []interface{}{
+				map[string]interface{}{
+					"label_name": aws.StringValue(c.LabelNameCondition.LabelName),
+				},
+			}
+		}
+
+		out = append(out, m)
+	}
+
+	return out
+}
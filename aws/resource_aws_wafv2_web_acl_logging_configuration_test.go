@@ -0,0 +1,507 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_basicFirehose(t *testing.T) {
+	var v wafv2.LoggingConfiguration
+	webACLResourceName := "aws_wafv2_web_acl.test"
+	resourceName := "aws_wafv2_web_acl_logging_configuration.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafv2WebACLLoggingConfigurationConfig_basicFirehose(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafv2WebACLLoggingConfigurationExists(resourceName, &v),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_arn", webACLResourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "log_destination_configs.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "redacted_fields.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_cloudWatchLogs(t *testing.T) {
+	var v wafv2.LoggingConfiguration
+	resourceName := "aws_wafv2_web_acl_logging_configuration.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafv2WebACLLoggingConfigurationConfig_cloudWatchLogs(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafv2WebACLLoggingConfigurationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "log_destination_configs.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_s3(t *testing.T) {
+	var v wafv2.LoggingConfiguration
+	resourceName := "aws_wafv2_web_acl_logging_configuration.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafv2WebACLLoggingConfigurationConfig_s3(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafv2WebACLLoggingConfigurationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "log_destination_configs.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_mixedDestinationTypes(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSWafv2WebACLLoggingConfigurationConfig_mixedDestinationTypes(rName),
+				ExpectError: regexp.MustCompile(`must all be the same destination type`),
+			},
+		},
+	})
+}
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_loggingFilter(t *testing.T) {
+	var v wafv2.LoggingConfiguration
+	resourceName := "aws_wafv2_web_acl_logging_configuration.test"
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilter(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSWafv2WebACLLoggingConfigurationExists(resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "logging_filter.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "logging_filter.0.default_behavior", wafv2.FilterBehaviorKeep),
+					resource.TestCheckResourceAttr(resourceName, "logging_filter.0.filter.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSWafv2WebACLLoggingConfiguration_loggingFilterConditionRequiresExactlyOne(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilterNoCondition(rName),
+				ExpectError: regexp.MustCompile(`exactly one of "action_condition" or "label_name_condition" must be set`),
+			},
+			{
+				Config:      testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilterBothConditions(rName),
+				ExpectError: regexp.MustCompile(`exactly one of "action_condition" or "label_name_condition" must be set`),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSWafv2WebACLLoggingConfigurationExists(n string, v *wafv2.LoggingConfiguration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no WAFv2 Logging Configuration ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).wafv2conn
+		output, err := conn.GetLoggingConfiguration(&wafv2.GetLoggingConfigurationInput{
+			ResourceArn: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if output == nil || output.LoggingConfiguration == nil {
+			return fmt.Errorf("WAFv2 Logging Configuration (%s) not found", rs.Primary.ID)
+		}
+
+		*v = *output.LoggingConfiguration
+
+		return nil
+	}
+}
+
+func testAccCheckAWSWafv2WebACLLoggingConfigurationDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).wafv2conn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_wafv2_web_acl_logging_configuration" {
+			continue
+		}
+
+		output, err := conn.GetLoggingConfiguration(&wafv2.GetLoggingConfigurationInput{
+			ResourceArn: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			if isAWSErr(err, wafv2.ErrCodeWAFNonexistentItemException, "") {
+				continue
+			}
+			return err
+		}
+
+		if output != nil && output.LoggingConfiguration != nil {
+			return fmt.Errorf("WAFv2 Logging Configuration for WebACL %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_wafv2_web_acl" "test" {
+  name  = %[1]q
+  scope = "REGIONAL"
+
+  default_action {
+    allow {}
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = false
+    metric_name                = %[1]q
+    sampled_requests_enabled   = false
+  }
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_basicFirehose(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_s3_bucket" "test" {
+  bucket = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_kinesis_firehose_delivery_stream" "test" {
+  name        = "aws-waf-logs-%[1]s"
+  destination = "extended_s3"
+
+  extended_s3_configuration {
+    role_arn   = aws_iam_role.test.arn
+    bucket_arn = aws_s3_bucket.test.arn
+  }
+}
+
+resource "aws_iam_role" "test" {
+  name = %[1]q
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Action    = "sts:AssumeRole"
+      Effect    = "Allow"
+      Principal = { Service = "firehose.amazonaws.com" }
+    }]
+  })
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_kinesis_firehose_delivery_stream.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_cloudWatchLogs(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_cloudwatch_log_group.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_s3(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_s3_bucket" "test" {
+  bucket = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_s3_bucket.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilter(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_cloudwatch_log_group.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+
+  logging_filter {
+    default_behavior = "KEEP"
+
+    filter {
+      behavior    = "DROP"
+      requirement = "MEETS_ALL"
+
+      condition {
+        action_condition {
+          action = "COUNT"
+        }
+      }
+    }
+
+    filter {
+      behavior    = "DROP"
+      requirement = "MEETS_ANY"
+
+      condition {
+        label_name_condition {
+          label_name = "awswaf:managed:aws:bot-control:bot:low-confidence"
+        }
+      }
+
+      condition {
+        action_condition {
+          action = "ALLOW"
+        }
+      }
+    }
+  }
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilterNoCondition(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_cloudwatch_log_group.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+
+  logging_filter {
+    default_behavior = "KEEP"
+
+    filter {
+      behavior    = "DROP"
+      requirement = "MEETS_ALL"
+
+      condition {}
+    }
+  }
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_loggingFilterBothConditions(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [aws_cloudwatch_log_group.test.arn]
+  resource_arn             = aws_wafv2_web_acl.test.arn
+
+  logging_filter {
+    default_behavior = "KEEP"
+
+    filter {
+      behavior    = "DROP"
+      requirement = "MEETS_ALL"
+
+      condition {
+        action_condition {
+          action = "COUNT"
+        }
+        label_name_condition {
+          label_name = "awswaf:managed:aws:bot-control:bot:low-confidence"
+        }
+      }
+    }
+  }
+}
+`, rName)
+}
+
+func testAccAWSWafv2WebACLLoggingConfigurationConfig_mixedDestinationTypes(rName string) string {
+	return testAccAWSWafv2WebACLLoggingConfigurationConfig_webACL(rName) + fmt.Sprintf(`
+resource "aws_s3_bucket" "test" {
+  bucket = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_cloudwatch_log_group" "test" {
+  name = "aws-waf-logs-%[1]s"
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "test" {
+  log_destination_configs = [
+    aws_s3_bucket.test.arn,
+    aws_cloudwatch_log_group.test.arn,
+  ]
+  resource_arn = aws_wafv2_web_acl.test.arn
+}
+`, rName)
+}
+
+func TestCanonicalizeWafv2RedactedFields(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Fields   []interface{}
+		Expected []string
+	}{
+		{
+			Name:     "empty",
+			Fields:   []interface{}{},
+			Expected: []string{},
+		},
+		{
+			Name: "mixed field types",
+			Fields: []interface{}{
+				map[string]interface{}{"method": []interface{}{map[string]interface{}{}}},
+				map[string]interface{}{"query_string": []interface{}{map[string]interface{}{}}},
+				map[string]interface{}{"uri_path": []interface{}{map[string]interface{}{}}},
+				map[string]interface{}{
+					"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+				},
+			},
+			Expected: []string{"method:", "query_string:", "single_header:cookie", "uri_path:"},
+		},
+		{
+			Name: "duplicate entries",
+			Fields: []interface{}{
+				map[string]interface{}{
+					"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+				},
+				map[string]interface{}{
+					"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+				},
+			},
+			Expected: []string{"single_header:cookie", "single_header:cookie"},
+		},
+		{
+			Name: "header name case differences are folded",
+			Fields: []interface{}{
+				map[string]interface{}{
+					"single_header": []interface{}{map[string]interface{}{"name": "Cookie"}},
+				},
+				map[string]interface{}{
+					"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+				},
+			},
+			Expected: []string{"single_header:cookie", "single_header:cookie"},
+		},
+		{
+			Name: "single_query_argument is compared by name",
+			Fields: []interface{}{
+				map[string]interface{}{
+					"single_query_argument": []interface{}{map[string]interface{}{"name": "id"}},
+				},
+			},
+			Expected: []string{"single_query_argument:id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			got := canonicalizeWafv2RedactedFields(tc.Fields)
+			sort.Strings(got)
+			want := append([]string{}, tc.Expected...)
+			sort.Strings(want)
+
+			if len(got) != len(want) {
+				t.Fatalf("canonicalizeWafv2RedactedFields() = %v, want %v", got, want)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("canonicalizeWafv2RedactedFields() = %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentRedactedFields_ordering(t *testing.T) {
+	a := []interface{}{
+		map[string]interface{}{"method": []interface{}{map[string]interface{}{}}},
+		map[string]interface{}{
+			"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+		},
+	}
+	b := []interface{}{
+		map[string]interface{}{
+			"single_header": []interface{}{map[string]interface{}{"name": "cookie"}},
+		},
+		map[string]interface{}{"method": []interface{}{map[string]interface{}{}}},
+	}
+
+	aKeys := canonicalizeWafv2RedactedFields(a)
+	bKeys := canonicalizeWafv2RedactedFields(b)
+	sort.Strings(aKeys)
+	sort.Strings(bKeys)
+
+	if len(aKeys) != len(bKeys) {
+		t.Fatalf("expected equivalent field sets regardless of ordering, got %v and %v", aKeys, bKeys)
+	}
+	for i := range aKeys {
+		if aKeys[i] != bKeys[i] {
+			t.Fatalf("expected equivalent field sets regardless of ordering, got %v and %v", aKeys, bKeys)
+		}
+	}
+}